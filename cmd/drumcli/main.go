@@ -0,0 +1,37 @@
+// Command drumcli is a terminal grid editor for .splice drum pattern files.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/jabley/golang-challenge-1"
+	"github.com/jabley/golang-challenge-1/editor"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: %s <path-to.splice>\n", os.Args[0])
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	path := flag.Arg(0)
+
+	p, err := drum.DecodeFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "drumcli: %v\n", err)
+		os.Exit(1)
+	}
+
+	doc := editor.NewDocument(p)
+	ui := editor.NewTermboxUI(path)
+	if err := ui.Run(doc); err != nil {
+		fmt.Fprintf(os.Stderr, "drumcli: %v\n", err)
+		os.Exit(1)
+	}
+}
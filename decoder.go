@@ -16,10 +16,26 @@ import (
 // ErrNotSPLICE is returned if the stream is not a SPLICE format
 var ErrNotSPLICE = errors.New("Not a SPLICE stream")
 
+// ErrTrailingGarbage is returned in strict mode when there are too few
+// bytes left in the pattern's payload to even hold a track header, or when
+// a track header declares a name longer than maxTrackNameLen.
+var ErrTrailingGarbage = errors.New("drum: trailing garbage after last track")
+
+// ErrTruncatedTrack is returned in strict mode when a track header was
+// read but its name or step data was cut short.
+var ErrTruncatedTrack = errors.New("drum: truncated track")
+
 // frameHeaderLen is the number of bytes required to express the header.
 // this does not include the magic marker
 const frameHeaderLen = 40
 
+// maxTrackNameLen bounds how large a single track's declared name length
+// is trusted to be before allocating a buffer for it. It is a fixed
+// constant rather than anything derived from the stream's own Length
+// fields, since those are attacker-controlled and can be forged to agree
+// with each other.
+const maxTrackNameLen = 4096
+
 type stickyErrReader struct {
 	r   io.Reader
 	err error
@@ -36,16 +52,17 @@ func (ser stickyErrReader) Read(p []byte) (n int, err error) {
 
 type framer struct {
 	br *bufio.Reader
+
+	// strict and maxPayload mirror DecoderOptions; see Decoder.Decode. The
+	// zero value (lenient, unlimited) is what DecodeFile uses.
+	strict     bool
+	maxPayload int64
 }
 
 // DecodeFile decodes the drum machine file found at the provided path
 // and returns a pointer to a parsed pattern which is the entry point to the
 // rest of the data.
-// TODO: implement
 func DecodeFile(path string) (*Pattern, error) {
-	// data, err := ioutil.ReadFile(path)
-	// dump(data)
-
 	file, err := os.Open(path)
 
 	if err != nil {
@@ -57,7 +74,11 @@ func DecodeFile(path string) (*Pattern, error) {
 	br := bufio.NewReader(stickyErrReader{r: file})
 	framer := newFramer(br)
 
-	return framer.readSplice()
+	p, err := framer.readSplice()
+	if err == io.EOF {
+		return nil, ErrNotSPLICE
+	}
+	return p, err
 }
 
 func newFramer(br *bufio.Reader) framer {
@@ -89,11 +110,22 @@ func (f *framer) readSplice() (*Pattern, error) {
 
 	err := binary.Read(f.br, binary.BigEndian, &hdr)
 
+	// A clean EOF before any byte of a new header is read means the
+	// stream is simply done, which matters to callers decoding a
+	// sequence of concatenated patterns (see Decoder.Decode).
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+
 	// Do we have something that looks like a valid SPLICE stream?
 	if err != nil || !bytes.Equal(hdr.MAGIC[:], []byte("SPLICE")) {
 		return nil, ErrNotSPLICE
 	}
 
+	if f.maxPayload > 0 && int64(hdr.Length) > f.maxPayload {
+		return nil, fmt.Errorf("drum: payload length %d exceeds max %d", hdr.Length, f.maxPayload)
+	}
+
 	p := &Pattern{length: int64(hdr.Length)}
 
 	// Limit how many bytes we'll read from this stream
@@ -114,6 +146,14 @@ func (f *framer) readSplice() (*Pattern, error) {
 
 	err = f.readTracks(r, p)
 
+	// Whatever is left of this pattern's declared payload needs to be
+	// consumed from the underlying reader before returning, regardless
+	// of where track parsing stopped (lenient early exit, trailing
+	// garbage, or an error) — otherwise a stream of concatenated
+	// patterns would leave the next readSplice starting mid-payload
+	// instead of at the next pattern's header.
+	io.Copy(io.Discard, r)
+
 	if err != nil {
 		return nil, err
 	}
@@ -166,27 +206,50 @@ func (f *framer) readTracks(r io.Reader, p *Pattern) error {
 		if err == io.EOF {
 			// no more tracks
 			break
+		} else if err == io.ErrUnexpectedEOF {
+			// Too few bytes left to even hold a track header: either
+			// report it or, leniently, treat it as the end of the
+			// pattern and keep what we've already parsed.
+			if f.strict {
+				return ErrTrailingGarbage
+			}
+			break
 		} else if err != nil {
 			return err
 		}
 
+		// Reject an implausible name length up front rather than
+		// trusting it to size an allocation: the pattern's own
+		// declared payload length can't be used as the bound here,
+		// since it's just as attacker-controlled as hdr.Length itself.
+		if hdr.Length > maxTrackNameLen {
+			if f.strict {
+				return ErrTrailingGarbage
+			}
+			break
+		}
+
 		if int(hdr.Length) > len(buf) {
 			buf = make([]byte, hdr.Length)
 		}
-		// fmt.Printf("name length: %v\n", nameLen)
-		if _, err := r.Read(buf[0:hdr.Length]); err != nil {
-			return err
+		if _, err := io.ReadFull(r, buf[0:hdr.Length]); err != nil {
+			if f.strict {
+				return ErrTruncatedTrack
+			}
+			break
 		}
 		name := string(buf[0:hdr.Length])
 
 		if steps == nil {
 			steps = make([]byte, 16)
 		}
-		// fmt.Printf("name: %v\n", name)
-		if _, err := r.Read(steps); err != nil {
-			return err
+		if _, err := io.ReadFull(r, steps); err != nil {
+			if f.strict {
+				return ErrTruncatedTrack
+			}
+			break
 		}
-		track := track{ID: strconv.Itoa(int(hdr.ID)), Name: name, Steps: f.bytesToBools(steps)}
+		track := Track{ID: strconv.Itoa(int(hdr.ID)), Name: name, Steps: f.bytesToBools(steps)}
 		p.Tracks = append(p.Tracks, track)
 	}
 	return nil
@@ -0,0 +1,50 @@
+package drum
+
+import (
+	"bufio"
+	"io"
+)
+
+// DecoderOptions configures a Decoder.
+type DecoderOptions struct {
+	// Strict makes Decode return a typed error (ErrTrailingGarbage or
+	// ErrTruncatedTrack) when a pattern's track data is malformed.
+	// The default, false, skips the bad data and returns the tracks
+	// successfully parsed so far.
+	Strict bool
+
+	// MaxPayload rejects any pattern whose declared payload length
+	// exceeds it, guarding against a corrupt length field asking for an
+	// unreasonable read. Zero (the default) means unlimited.
+	MaxPayload int64
+}
+
+// Decoder reads a sequence of zero or more SPLICE patterns from a stream,
+// such as a file produced by concatenating several .splice files together.
+type Decoder struct {
+	br      *bufio.Reader
+	Options DecoderOptions
+}
+
+// NewDecoder returns a Decoder that reads patterns from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{br: bufio.NewReader(stickyErrReader{r: r})}
+}
+
+// Decode reads and returns the next pattern from the stream. It returns
+// io.EOF once the stream is exhausted, so callers typically loop on it:
+//
+//	for {
+//		p, err := d.Decode()
+//		if err == io.EOF {
+//			break
+//		}
+//		if err != nil {
+//			// handle err, see DecoderOptions.Strict
+//		}
+//		// use p
+//	}
+func (d *Decoder) Decode() (*Pattern, error) {
+	fr := framer{br: d.br, strict: d.Options.Strict, maxPayload: d.Options.MaxPayload}
+	return fr.readSplice()
+}
@@ -0,0 +1,184 @@
+package drum
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+)
+
+func encodeFixture(t *testing.T, p *Pattern) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := Encode(&buf, p); err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecoderMultiplePatterns(t *testing.T) {
+	a := &Pattern{Version: "0.808", Tempo: 120, Tracks: []Track{{ID: "0", Name: "kick", Steps: make([]bool, 16)}}}
+	b := &Pattern{Version: "0.909", Tempo: 140, Tracks: []Track{{ID: "1", Name: "snare", Steps: make([]bool, 16)}}}
+
+	var stream bytes.Buffer
+	stream.Write(encodeFixture(t, a))
+	stream.Write(encodeFixture(t, b))
+
+	d := NewDecoder(&stream)
+
+	got1, err := d.Decode()
+	if err != nil {
+		t.Fatalf("first Decode() returned error: %v", err)
+	}
+	if got1.String() != a.String() {
+		t.Errorf("first pattern = %s, want %s", got1, a)
+	}
+
+	got2, err := d.Decode()
+	if err != nil {
+		t.Fatalf("second Decode() returned error: %v", err)
+	}
+	if got2.String() != b.String() {
+		t.Errorf("second pattern = %s, want %s", got2, b)
+	}
+
+	if _, err := d.Decode(); err != io.EOF {
+		t.Errorf("third Decode() error = %v, want io.EOF", err)
+	}
+}
+
+func TestDecoderLenientStopsAtTruncatedTrack(t *testing.T) {
+	p := &Pattern{Version: "0.808", Tempo: 120, Tracks: []Track{
+		{ID: "0", Name: "kick", Steps: make([]bool, 16)},
+		{ID: "1", Name: "snare", Steps: make([]bool, 16)},
+	}}
+	data := encodeFixture(t, p)
+
+	// Truncate mid-way through the second track, but leave the declared
+	// payload length untouched, as with fixture 5 in the challenge.
+	truncated := data[:len(data)-10]
+
+	d := NewDecoder(bytes.NewReader(truncated))
+	got, err := d.Decode()
+	if err != nil {
+		t.Fatalf("lenient Decode() returned error: %v", err)
+	}
+	if len(got.Tracks) != 1 {
+		t.Fatalf("len(Tracks) = %d, want 1 (only the complete track)", len(got.Tracks))
+	}
+	if got.Tracks[0].Name != "kick" {
+		t.Errorf("surviving track = %q, want %q", got.Tracks[0].Name, "kick")
+	}
+}
+
+func TestDecoderRealignsAfterTrailingGarbageForNextPattern(t *testing.T) {
+	a := &Pattern{Version: "0.808", Tempo: 120, Tracks: []Track{{ID: "0", Name: "kick", Steps: make([]bool, 16)}}}
+	b := &Pattern{Version: "0.909", Tempo: 140, Tracks: []Track{{ID: "1", Name: "snare", Steps: make([]bool, 16)}}}
+
+	dataA := encodeFixture(t, a)
+	garbage := []byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+
+	// Claim the garbage bytes as part of A's own declared payload, as
+	// with fixture 5 in the challenge, then concatenate B right after.
+	length := binary.BigEndian.Uint64(dataA[6:14])
+	binary.BigEndian.PutUint64(dataA[6:14], length+uint64(len(garbage)))
+
+	var stream bytes.Buffer
+	stream.Write(dataA)
+	stream.Write(garbage)
+	stream.Write(encodeFixture(t, b))
+
+	d := NewDecoder(&stream)
+
+	got1, err := d.Decode()
+	if err != nil {
+		t.Fatalf("first Decode() returned error: %v", err)
+	}
+	if got1.String() != a.String() {
+		t.Errorf("first pattern = %s, want %s", got1, a)
+	}
+
+	got2, err := d.Decode()
+	if err != nil {
+		t.Fatalf("second Decode() returned error: %v", err)
+	}
+	if got2.String() != b.String() {
+		t.Errorf("second pattern = %s, want %s", got2, b)
+	}
+}
+
+func TestDecoderStrictReturnsTruncatedTrack(t *testing.T) {
+	p := &Pattern{Version: "0.808", Tempo: 120, Tracks: []Track{
+		{ID: "0", Name: "kick", Steps: make([]bool, 16)},
+		{ID: "1", Name: "snare", Steps: make([]bool, 16)},
+	}}
+	data := encodeFixture(t, p)
+	truncated := data[:len(data)-10]
+
+	d := NewDecoder(bytes.NewReader(truncated))
+	d.Options.Strict = true
+
+	if _, err := d.Decode(); err != ErrTruncatedTrack {
+		t.Errorf("strict Decode() error = %v, want ErrTruncatedTrack", err)
+	}
+}
+
+func TestDecoderMaxPayload(t *testing.T) {
+	p := &Pattern{Version: "0.808", Tempo: 120, Tracks: []Track{{ID: "0", Name: "kick", Steps: make([]bool, 16)}}}
+	data := encodeFixture(t, p)
+
+	d := NewDecoder(bytes.NewReader(data))
+	d.Options.MaxPayload = 1
+
+	if _, err := d.Decode(); err == nil {
+		t.Error("expected an error when the payload exceeds MaxPayload")
+	}
+}
+
+func TestDecoderRejectsImplausibleTrackLength(t *testing.T) {
+	p := &Pattern{Version: "0.808", Tempo: 120, Tracks: []Track{{ID: "0", Name: "kick", Steps: make([]bool, 16)}}}
+	data := encodeFixture(t, p)
+
+	// Corrupt the first track's 32-bit name-length field to a value far
+	// larger than the declared payload could ever hold.
+	nameLenOffset := 6 + 8 + 32 + 4 + 1 // MAGIC, Length, Version, Tempo, track ID
+	data[nameLenOffset] = 0x7F
+	data[nameLenOffset+1] = 0xFF
+	data[nameLenOffset+2] = 0xFF
+	data[nameLenOffset+3] = 0xFF
+
+	d := NewDecoder(bytes.NewReader(data))
+	d.Options.Strict = true
+
+	if _, err := d.Decode(); err != ErrTrailingGarbage {
+		t.Errorf("strict Decode() error = %v, want ErrTrailingGarbage", err)
+	}
+}
+
+func TestDecoderRejectsHugeTrackLengthEvenWithMatchingPayloadLength(t *testing.T) {
+	// A forged stream whose top-level Length and per-track name-length
+	// are both ~2GB and mutually consistent, with MaxPayload left
+	// unset (the default). The per-track length must still be rejected
+	// on its own terms rather than trusted just because it agrees with
+	// the equally attacker-controlled top-level Length.
+	const hugeLen = 1 << 31
+
+	var buf bytes.Buffer
+	buf.WriteString("SPLICE")
+	binary.Write(&buf, binary.BigEndian, uint64(32+4+5+hugeLen))
+	var version [32]byte
+	copy(version[:], "0.808")
+	buf.Write(version[:])
+	binary.Write(&buf, binary.LittleEndian, float32(120))
+	buf.WriteByte(0) // track ID
+	binary.Write(&buf, binary.BigEndian, uint32(hugeLen))
+	// Deliberately no further bytes: a real attack wouldn't need to
+	// supply 2GB of name data for the allocation itself to be a problem.
+
+	d := NewDecoder(&buf)
+	d.Options.Strict = true
+
+	if _, err := d.Decode(); err != ErrTrailingGarbage {
+		t.Errorf("strict Decode() error = %v, want ErrTrailingGarbage", err)
+	}
+}
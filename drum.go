@@ -2,14 +2,17 @@
 // See golang-challenge.com/go-challenge1/ for more information
 package drum
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+)
 
 // Pattern is the high level representation of the
 // drum pattern contained in a .splice file.
 type Pattern struct {
 	Version string
 	Tempo   float32
-	Tracks  []track
+	Tracks  []Track
 	length  int64
 }
 
@@ -23,17 +26,38 @@ func (p *Pattern) String() string {
 
 }
 
-type track struct {
+// AddTrack appends a new, empty 16-step track named name to p, assigning
+// it one past the highest track ID currently in use.
+func (p *Pattern) AddTrack(name string) {
+	id := 0
+	for _, t := range p.Tracks {
+		if n, err := strconv.Atoi(t.ID); err == nil && n >= id {
+			id = n + 1
+		}
+	}
+	p.Tracks = append(p.Tracks, Track{ID: strconv.Itoa(id), Name: name, Steps: make([]bool, 16)})
+}
+
+// RemoveTrack removes the track at index i.
+func (p *Pattern) RemoveTrack(i int) error {
+	if i < 0 || i >= len(p.Tracks) {
+		return fmt.Errorf("drum: track index %d out of range", i)
+	}
+	p.Tracks = append(p.Tracks[:i], p.Tracks[i+1:]...)
+	return nil
+}
+
+type Track struct {
 	ID    string
 	Name  string
 	Steps []bool
 }
 
-func (t *track) String() string {
+func (t *Track) String() string {
 	return "(" + t.ID + ") " + t.Name + "\t" + t.FormatSteps() + "\n"
 }
 
-func (t *track) FormatSteps() string {
+func (t *Track) FormatSteps() string {
 	res := "|"
 
 	for i, s := range t.Steps {
@@ -0,0 +1,87 @@
+// Package editor implements an interactive grid editor for drum.Pattern,
+// independent of any particular terminal or GUI toolkit: Document holds the
+// editable state and EditorUI is the seam a concrete front end implements.
+package editor
+
+import (
+	"fmt"
+
+	"github.com/jabley/golang-challenge-1"
+)
+
+const steps = 16
+
+// Document is a Pattern open for editing, plus the session-only settings
+// (such as swing) that aren't part of the SPLICE wire format.
+type Document struct {
+	Pattern *drum.Pattern
+
+	// Swing is the per-pattern shuffle amount, 0-100, applied at playback
+	// time to delay off-beat steps. It is not persisted by Save, since
+	// the SPLICE format has no field for it.
+	Swing int
+}
+
+// NewDocument wraps p for editing.
+func NewDocument(p *drum.Pattern) *Document {
+	return &Document{Pattern: p}
+}
+
+// ToggleStep flips the on/off state of the given track's step.
+func (d *Document) ToggleStep(track, step int) error {
+	t, err := d.track(track)
+	if err != nil {
+		return err
+	}
+	if step < 0 || step >= len(t.Steps) {
+		return fmt.Errorf("editor: step index %d out of range", step)
+	}
+	t.Steps[step] = !t.Steps[step]
+	return nil
+}
+
+// RenameTrack sets the display name of the given track.
+func (d *Document) RenameTrack(track int, name string) error {
+	t, err := d.track(track)
+	if err != nil {
+		return err
+	}
+	t.Name = name
+	return nil
+}
+
+// SetTempo changes the pattern's playback tempo, in BPM.
+func (d *Document) SetTempo(tempo float32) {
+	d.Pattern.Tempo = tempo
+}
+
+// AddTrack appends a new, empty track named name.
+func (d *Document) AddTrack(name string) {
+	d.Pattern.AddTrack(name)
+}
+
+// RemoveTrack removes the track at index i.
+func (d *Document) RemoveTrack(i int) error {
+	return d.Pattern.RemoveTrack(i)
+}
+
+// SetSwing sets the shuffle amount, 0-100, applied at playback time.
+func (d *Document) SetSwing(percent int) error {
+	if percent < 0 || percent > 100 {
+		return fmt.Errorf("editor: swing %d%% out of range, want 0-100", percent)
+	}
+	d.Swing = percent
+	return nil
+}
+
+// Save writes the pattern to path in SPLICE format.
+func (d *Document) Save(path string) error {
+	return drum.EncodeFile(d.Pattern, path)
+}
+
+func (d *Document) track(i int) (*drum.Track, error) {
+	if i < 0 || i >= len(d.Pattern.Tracks) {
+		return nil, fmt.Errorf("editor: track index %d out of range", i)
+	}
+	return &d.Pattern.Tracks[i], nil
+}
@@ -0,0 +1,80 @@
+package editor
+
+import (
+	"testing"
+
+	"github.com/jabley/golang-challenge-1"
+)
+
+func newTestDocument() *Document {
+	p := &drum.Pattern{Version: "0.808", Tempo: 120}
+	p.AddTrack("kick")
+	p.AddTrack("snare")
+	return NewDocument(p)
+}
+
+func TestToggleStep(t *testing.T) {
+	doc := newTestDocument()
+
+	if err := doc.ToggleStep(0, 4); err != nil {
+		t.Fatalf("ToggleStep returned error: %v", err)
+	}
+	if !doc.Pattern.Tracks[0].Steps[4] {
+		t.Error("step 4 on track 0 should be on after toggling")
+	}
+
+	if err := doc.ToggleStep(0, 4); err != nil {
+		t.Fatalf("ToggleStep returned error: %v", err)
+	}
+	if doc.Pattern.Tracks[0].Steps[4] {
+		t.Error("step 4 on track 0 should be off after toggling twice")
+	}
+
+	if err := doc.ToggleStep(5, 0); err == nil {
+		t.Error("expected an error for an out-of-range track index")
+	}
+}
+
+func TestRenameTrack(t *testing.T) {
+	doc := newTestDocument()
+
+	if err := doc.RenameTrack(1, "closed-hh"); err != nil {
+		t.Fatalf("RenameTrack returned error: %v", err)
+	}
+	if got := doc.Pattern.Tracks[1].Name; got != "closed-hh" {
+		t.Errorf("track name = %q, want %q", got, "closed-hh")
+	}
+}
+
+func TestAddAndRemoveTrack(t *testing.T) {
+	doc := newTestDocument()
+
+	doc.AddTrack("clap")
+	if len(doc.Pattern.Tracks) != 3 {
+		t.Fatalf("len(Tracks) = %d, want 3", len(doc.Pattern.Tracks))
+	}
+
+	if err := doc.RemoveTrack(1); err != nil {
+		t.Fatalf("RemoveTrack returned error: %v", err)
+	}
+	if len(doc.Pattern.Tracks) != 2 {
+		t.Fatalf("len(Tracks) = %d, want 2", len(doc.Pattern.Tracks))
+	}
+	if doc.Pattern.Tracks[1].Name != "clap" {
+		t.Errorf("remaining tracks = %v, want [kick clap]", doc.Pattern.Tracks)
+	}
+}
+
+func TestSetSwing(t *testing.T) {
+	doc := newTestDocument()
+
+	if err := doc.SetSwing(150); err == nil {
+		t.Error("expected an error for swing over 100%")
+	}
+	if err := doc.SetSwing(50); err != nil {
+		t.Fatalf("SetSwing returned error: %v", err)
+	}
+	if doc.Swing != 50 {
+		t.Errorf("Swing = %d, want 50", doc.Swing)
+	}
+}
@@ -0,0 +1,218 @@
+package editor
+
+import (
+	"fmt"
+	"strconv"
+
+	termbox "github.com/nsf/termbox-go"
+
+	"github.com/jabley/golang-challenge-1/player"
+)
+
+// previewSeconds is how long a 'p' preview plays before returning control
+// to the editor.
+const previewSeconds = 2
+
+// TermboxUI is the default EditorUI, a full-screen grid editor built on
+// termbox-go. Construct it with NewTermboxUI.
+type TermboxUI struct {
+	path string
+
+	track int
+	step  int
+	msg   string
+}
+
+// NewTermboxUI returns a TermboxUI that saves to path on 'w'.
+func NewTermboxUI(path string) *TermboxUI {
+	return &TermboxUI{path: path}
+}
+
+// Run takes over the terminal and drives the edit loop until the user
+// quits with 'q' or Esc. Quitting does not save and does not ask about
+// unsaved changes.
+func (ui *TermboxUI) Run(doc *Document) error {
+	if err := termbox.Init(); err != nil {
+		return err
+	}
+	defer termbox.Close()
+
+	for {
+		ui.draw(doc)
+
+		ev := termbox.PollEvent()
+		if ev.Type != termbox.EventKey {
+			continue
+		}
+
+		switch {
+		case ev.Key == termbox.KeyEsc || ev.Ch == 'q':
+			return nil
+		case ev.Key == termbox.KeyArrowUp:
+			ui.track = ui.clampTrack(doc, ui.track-1)
+		case ev.Key == termbox.KeyArrowDown:
+			ui.track = ui.clampTrack(doc, ui.track+1)
+		case ev.Key == termbox.KeyArrowLeft:
+			ui.step = ui.clampStep(ui.step - 1)
+		case ev.Key == termbox.KeyArrowRight:
+			ui.step = ui.clampStep(ui.step + 1)
+		case ev.Ch == ' ':
+			ui.act(doc.ToggleStep(ui.track, ui.step))
+		case ev.Ch == 'n':
+			name, ok := ui.prompt("Rename track to: ")
+			if ok {
+				ui.act(doc.RenameTrack(ui.track, name))
+			}
+		case ev.Ch == 't':
+			raw, ok := ui.prompt("New tempo (BPM): ")
+			if ok {
+				if v, err := strconv.ParseFloat(raw, 32); err == nil {
+					doc.SetTempo(float32(v))
+				} else {
+					ui.msg = "invalid tempo: " + raw
+				}
+			}
+		case ev.Ch == 's':
+			raw, ok := ui.prompt("Swing % (0-100): ")
+			if ok {
+				v, err := strconv.Atoi(raw)
+				if err != nil {
+					ui.msg = "invalid swing: " + raw
+					break
+				}
+				ui.act(doc.SetSwing(v))
+			}
+		case ev.Ch == 'a':
+			name, ok := ui.prompt("New track name: ")
+			if ok {
+				doc.AddTrack(name)
+			}
+		case ev.Ch == 'x':
+			ui.act(doc.RemoveTrack(ui.track))
+			ui.track = ui.clampTrack(doc, ui.track)
+		case ev.Ch == 'w':
+			if err := doc.Save(ui.path); err != nil {
+				ui.msg = err.Error()
+			} else {
+				ui.msg = "saved " + ui.path
+			}
+		case ev.Ch == 'p':
+			ui.act(ui.preview(doc))
+		}
+	}
+}
+
+// preview plays doc's pattern through the player package, applying the
+// document's swing setting, so swing can be heard before it's lost (it
+// isn't part of the SPLICE format Save writes).
+func (ui *TermboxUI) preview(doc *Document) error {
+	pl := player.NewPlayer(player.NewDirResolver(player.SampleDir))
+	pl.Swing = doc.Swing
+	return pl.Play(doc.Pattern, previewSeconds)
+}
+
+func (ui *TermboxUI) act(err error) {
+	if err != nil {
+		ui.msg = err.Error()
+	}
+}
+
+func (ui *TermboxUI) clampTrack(doc *Document, track int) int {
+	switch {
+	case len(doc.Pattern.Tracks) == 0:
+		return 0
+	case track < 0:
+		return 0
+	case track >= len(doc.Pattern.Tracks):
+		return len(doc.Pattern.Tracks) - 1
+	default:
+		return track
+	}
+}
+
+func (ui *TermboxUI) clampStep(step int) int {
+	switch {
+	case step < 0:
+		return 0
+	case step >= steps:
+		return steps - 1
+	default:
+		return step
+	}
+}
+
+// draw renders the track grid, the header and a trailing status line.
+func (ui *TermboxUI) draw(doc *Document) {
+	termbox.Clear(termbox.ColorDefault, termbox.ColorDefault)
+
+	header := fmt.Sprintf("Version: %s  Tempo: %g  Swing: %d%%", doc.Pattern.Version, doc.Pattern.Tempo, doc.Swing)
+	ui.print(0, 0, header)
+
+	for row, t := range doc.Pattern.Tracks {
+		y := row + 2
+		label := fmt.Sprintf("(%s) %-12s", t.ID, t.Name)
+		ui.print(0, y, label)
+
+		for col, on := range t.Steps {
+			x := len(label) + col*2
+			ch := '-'
+			if on {
+				ch = 'x'
+			}
+			fg := termbox.ColorDefault
+			if row == ui.track && col == ui.step {
+				fg = termbox.ColorBlack | termbox.AttrBold
+				termbox.SetCell(x, y, ch, fg, termbox.ColorWhite)
+				continue
+			}
+			termbox.SetCell(x, y, ch, fg, termbox.ColorDefault)
+		}
+	}
+
+	footer := "arrows: move  space: toggle  n: rename  a: add  x: remove  t: tempo  s: swing  p: preview  w: save  q: quit"
+	ui.print(0, len(doc.Pattern.Tracks)+3, footer)
+	if ui.msg != "" {
+		ui.print(0, len(doc.Pattern.Tracks)+4, ui.msg)
+	}
+
+	termbox.Flush()
+}
+
+func (ui *TermboxUI) print(x, y int, s string) {
+	for i, r := range s {
+		termbox.SetCell(x+i, y, r, termbox.ColorDefault, termbox.ColorDefault)
+	}
+}
+
+// prompt clears the screen to a single line-input prompt at the top and
+// returns the entered text, or ok=false if the user cancelled with Esc.
+func (ui *TermboxUI) prompt(label string) (value string, ok bool) {
+	var input []rune
+	for {
+		termbox.Clear(termbox.ColorDefault, termbox.ColorDefault)
+		ui.print(0, 0, label+string(input))
+		termbox.Flush()
+
+		ev := termbox.PollEvent()
+		if ev.Type != termbox.EventKey {
+			continue
+		}
+
+		switch ev.Key {
+		case termbox.KeyEnter:
+			return string(input), true
+		case termbox.KeyEsc:
+			return "", false
+		case termbox.KeyBackspace, termbox.KeyBackspace2:
+			if len(input) > 0 {
+				input = input[:len(input)-1]
+			}
+		case termbox.KeySpace:
+			input = append(input, ' ')
+		default:
+			if ev.Ch != 0 {
+				input = append(input, ev.Ch)
+			}
+		}
+	}
+}
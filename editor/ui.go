@@ -0,0 +1,8 @@
+package editor
+
+// EditorUI renders a Document and drives the edit loop until the user
+// quits. Run returns when the user asks to exit; the caller does not save
+// doc afterwards, so any saving happens inside Run.
+type EditorUI interface {
+	Run(doc *Document) error
+}
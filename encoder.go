@@ -0,0 +1,134 @@
+package drum
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+type encoder struct {
+	w io.Writer
+}
+
+// EncodeFile writes p to the provided path in SPLICE format, creating the
+// file if it does not already exist and truncating it otherwise.
+func EncodeFile(p *Pattern, path string) error {
+	file, err := os.Create(path)
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	return Encode(file, p)
+}
+
+// Encode writes p to w as a SPLICE stream: MAGIC, big-endian payload length,
+// a 32-byte null-padded version, a little-endian float32 tempo and the
+// per-track blocks described by writeSplice.
+func Encode(w io.Writer, p *Pattern) error {
+	enc := encoder{w: w}
+	return enc.writeSplice(p)
+}
+
+// +--------------+
+// |MAGIC(48bits) |
+// +----------------+
+// | Length(64bits) |
+// +-------------------------------------------+
+// | Version (null-terminated string, 256bits) |
+// +-------------------------------------------+
+// | Tempo (32bits float32)           |
+// +----------------------------------+
+// |     track block                  |
+// |              ...                 |
+func (e *encoder) writeSplice(p *Pattern) error {
+	var version [32]byte
+	copy(version[:], p.Version)
+
+	var tracks bytes.Buffer
+	if err := e.writeTracks(&tracks, p); err != nil {
+		return err
+	}
+
+	hdr := struct {
+		MAGIC   [6]byte
+		Length  uint64
+		Version [32]byte
+	}{
+		Length:  uint64(len(version) + 4 + tracks.Len()),
+		Version: version,
+	}
+	copy(hdr.MAGIC[:], "SPLICE")
+
+	if err := binary.Write(e.w, binary.BigEndian, &hdr); err != nil {
+		return err
+	}
+
+	tempo := struct {
+		Value float32
+	}{Value: p.Tempo}
+
+	if err := binary.Write(e.w, binary.LittleEndian, &tempo); err != nil {
+		return err
+	}
+
+	_, err := e.w.Write(tracks.Bytes())
+	return err
+}
+
+// +-----------+
+// | id(8bits) |
+// +----------------------------------+
+// | length (32bits)                  |
+// +----------------------------------+
+// | name (ascii string, length bytes)|
+// +----------------------------------+
+// | steps (128bits)                  |
+// +----------------------------------+
+func (e *encoder) writeTracks(w io.Writer, p *Pattern) error {
+	for _, t := range p.Tracks {
+		if len(t.Steps) != 16 {
+			return fmt.Errorf("drum: track %q has %d steps, want 16", t.Name, len(t.Steps))
+		}
+
+		id, err := strconv.ParseUint(t.ID, 10, 8)
+		if err != nil {
+			return fmt.Errorf("drum: invalid track id %q: %v", t.ID, err)
+		}
+
+		hdr := struct {
+			ID     uint8
+			Length uint32
+		}{ID: uint8(id), Length: uint32(len(t.Name))}
+
+		if err := binary.Write(w, binary.BigEndian, &hdr); err != nil {
+			return err
+		}
+
+		if _, err := io.WriteString(w, t.Name); err != nil {
+			return err
+		}
+
+		if _, err := w.Write(e.boolsToBytes(t.Steps)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// boolsToBytes maps an array of bools to an array of bytes, the inverse of
+// framer.bytesToBools.
+func (e *encoder) boolsToBytes(steps []bool) []byte {
+	res := make([]byte, len(steps))
+	for i, s := range steps {
+		if s {
+			res[i] = 1
+		}
+	}
+	return res
+}
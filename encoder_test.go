@@ -0,0 +1,61 @@
+package drum
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+)
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	tests := []*Pattern{
+		{
+			Version: "0.808-alpha",
+			Tempo:   120,
+			Tracks: []Track{
+				{ID: "0", Name: "kick", Steps: []bool{true, false, false, false, true, false, false, false, true, false, false, false, true, false, false, false}},
+				{ID: "1", Name: "snare", Steps: []bool{false, false, false, false, true, false, false, false, false, false, false, false, true, false, false, false}},
+			},
+		},
+		{
+			Version: "0.909",
+			Tempo:   98.4,
+			Tracks: []Track{
+				{ID: "255", Name: "", Steps: make([]bool, 16)},
+				{ID: "3", Name: "closed-hh", Steps: make([]bool, 16)},
+			},
+		},
+	}
+
+	for _, want := range tests {
+		var buf bytes.Buffer
+		if err := Encode(&buf, want); err != nil {
+			t.Fatalf("Encode(%v) returned error: %v", want, err)
+		}
+
+		fr := newFramer(bufio.NewReader(&buf))
+		got, err := fr.readSplice()
+		if err != nil {
+			t.Fatalf("readSplice() after Encode returned error: %v", err)
+		}
+
+		if got.String() != want.String() {
+			t.Errorf("round-trip mismatch:\n got: %s\nwant: %s", got, want)
+		}
+	}
+}
+
+func TestEncodeRejectsWrongStepCount(t *testing.T) {
+	p := &Pattern{
+		Version: "0.808",
+		Tempo:   120,
+		Tracks: []Track{
+			{ID: "0", Name: "kick", Steps: make([]bool, 3)},
+			{ID: "1", Name: "snare", Steps: make([]bool, 16)},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, p); err == nil {
+		t.Error("expected an error encoding a track with a step count other than 16")
+	}
+}
@@ -0,0 +1,50 @@
+package drum
+
+import "encoding/json"
+
+// patternJSON and trackJSON give Pattern a stable JSON shape independent
+// of Pattern's Go field layout, so field names don't shift if the struct
+// does.
+type patternJSON struct {
+	Version string      `json:"version"`
+	Tempo   float32     `json:"tempo"`
+	Tracks  []trackJSON `json:"tracks"`
+}
+
+type trackJSON struct {
+	ID    string   `json:"id"`
+	Name  string   `json:"name"`
+	Steps [16]bool `json:"steps"`
+}
+
+// MarshalJSON encodes p with stable field names, representing each
+// track's steps as a 16-element bool array.
+func (p *Pattern) MarshalJSON() ([]byte, error) {
+	pj := patternJSON{
+		Version: p.Version,
+		Tempo:   p.Tempo,
+		Tracks:  make([]trackJSON, len(p.Tracks)),
+	}
+	for i, t := range p.Tracks {
+		tj := trackJSON{ID: t.ID, Name: t.Name}
+		copy(tj.Steps[:], t.Steps)
+		pj.Tracks[i] = tj
+	}
+	return json.Marshal(pj)
+}
+
+// UnmarshalJSON decodes a Pattern previously written by MarshalJSON.
+func (p *Pattern) UnmarshalJSON(data []byte) error {
+	var pj patternJSON
+	if err := json.Unmarshal(data, &pj); err != nil {
+		return err
+	}
+
+	p.Version = pj.Version
+	p.Tempo = pj.Tempo
+	p.Tracks = make([]Track, len(pj.Tracks))
+	for i, tj := range pj.Tracks {
+		p.Tracks[i] = Track{ID: tj.ID, Name: tj.Name, Steps: append([]bool(nil), tj.Steps[:]...)}
+	}
+	return nil
+}
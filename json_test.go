@@ -0,0 +1,50 @@
+package drum
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPatternJSONRoundTrip(t *testing.T) {
+	want := &Pattern{
+		Version: "0.808-alpha",
+		Tempo:   120,
+		Tracks: []Track{
+			{ID: "0", Name: "kick", Steps: []bool{true, false, false, false, true, false, false, false, true, false, false, false, true, false, false, false}},
+			{ID: "1", Name: "snare", Steps: make([]bool, 16)},
+		},
+	}
+
+	data, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+
+	got := &Pattern{}
+	if err := json.Unmarshal(data, got); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+
+	if got.String() != want.String() {
+		t.Errorf("round-trip mismatch:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestPatternJSONFieldNames(t *testing.T) {
+	p := &Pattern{Version: "0.808", Tempo: 120, Tracks: []Track{{ID: "0", Name: "kick", Steps: make([]bool, 16)}}}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+	for _, field := range []string{"version", "tempo", "tracks"} {
+		if _, ok := raw[field]; !ok {
+			t.Errorf("missing expected field %q in %s", field, data)
+		}
+	}
+}
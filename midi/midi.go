@@ -0,0 +1,208 @@
+// Package midi exports a drum.Pattern as a type-0 Standard MIDI File, one
+// note-on/note-off pair per active step, so a pattern can be opened in any
+// DAW rather than just this codec's own tools.
+package midi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/jabley/golang-challenge-1"
+)
+
+// ticksPerQuarterNote is the MIDI file's time division: 96 ticks per
+// quarter note, giving a whole number of ticks (24) per 16th-note step.
+const ticksPerQuarterNote = 96
+
+const ticksPerStep = ticksPerQuarterNote / 4
+
+const steps = 16
+
+// drumChannel is MIDI channel 10 (zero-indexed 9), the General MIDI
+// percussion channel.
+const drumChannel = 9
+
+// DefaultDrumMap maps common drum.Pattern track names to General MIDI
+// percussion notes. Names are matched case-sensitively; tracks with no
+// entry here and none in MIDIOptions.DrumMap fall back to DefaultNote.
+var DefaultDrumMap = map[string]uint8{
+	"kick":      36,
+	"kick2":     35,
+	"snare":     38,
+	"clap":      39,
+	"low-tom":   45,
+	"mid-tom":   47,
+	"hi-tom":    50,
+	"hh-closed": 42,
+	"hh-open":   46,
+	"crash":     49,
+	"ride":      51,
+}
+
+// DefaultNote is the General MIDI note used for a track whose name isn't
+// found in the drum map.
+const DefaultNote uint8 = 37 // side stick
+
+// MIDIOptions configures ExportMIDI.
+type MIDIOptions struct {
+	// DrumMap overrides DefaultDrumMap for looking up a track's MIDI
+	// note by name. A nil or zero-value entry falls through to
+	// DefaultDrumMap, then to DefaultNote.
+	DrumMap map[string]uint8
+}
+
+// note returns the MIDI note for track name, preferring opts.DrumMap,
+// then DefaultDrumMap, then DefaultNote.
+func (opts MIDIOptions) note(name string) uint8 {
+	if n, ok := opts.DrumMap[name]; ok && n != 0 {
+		return n
+	}
+	if n, ok := DefaultDrumMap[name]; ok {
+		return n
+	}
+	return DefaultNote
+}
+
+type event struct {
+	tick   uint32
+	status byte
+	data1  byte
+	data2  byte
+}
+
+// ExportMIDI writes p to w as a type-0 Standard MIDI File: a tempo meta
+// event followed by one bar of 16th-note note-on/note-off pairs, one per
+// active step, on the General MIDI percussion channel.
+func ExportMIDI(p *drum.Pattern, w io.Writer, opts MIDIOptions) error {
+	track, err := buildTrackChunk(p, opts)
+	if err != nil {
+		return err
+	}
+
+	if err := writeHeaderChunk(w); err != nil {
+		return err
+	}
+	_, err = w.Write(track)
+	return err
+}
+
+func writeHeaderChunk(w io.Writer) error {
+	hdr := struct {
+		ChunkID  [4]byte
+		Length   uint32
+		Format   uint16
+		NumTrack uint16
+		Division uint16
+	}{
+		Length:   6,
+		Format:   0,
+		NumTrack: 1,
+		Division: ticksPerQuarterNote,
+	}
+	copy(hdr.ChunkID[:], "MThd")
+	return binary.Write(w, binary.BigEndian, &hdr)
+}
+
+func buildTrackChunk(p *drum.Pattern, opts MIDIOptions) ([]byte, error) {
+	var body bytes.Buffer
+
+	if err := writeTempoEvent(&body, p.Tempo); err != nil {
+		return nil, err
+	}
+
+	events := buildEvents(p, opts)
+	if err := writeEvents(&body, events); err != nil {
+		return nil, err
+	}
+
+	// End of track.
+	if err := writeVLQ(&body, 0); err != nil {
+		return nil, err
+	}
+	body.Write([]byte{0xFF, 0x2F, 0x00})
+
+	var chunk bytes.Buffer
+	chunk.WriteString("MTrk")
+	if err := binary.Write(&chunk, binary.BigEndian, uint32(body.Len())); err != nil {
+		return nil, err
+	}
+	chunk.Write(body.Bytes())
+	return chunk.Bytes(), nil
+}
+
+// writeTempoEvent emits an FF 51 03 set-tempo meta event for tempo BPM,
+// at tick 0.
+func writeTempoEvent(w io.Writer, tempo float32) error {
+	if err := writeVLQ(w, 0); err != nil {
+		return err
+	}
+	if tempo <= 0 {
+		return fmt.Errorf("midi: invalid tempo %v", tempo)
+	}
+	microsPerQuarter := uint32(60000000 / float64(tempo))
+	if _, err := w.Write([]byte{0xFF, 0x51, 0x03}); err != nil {
+		return err
+	}
+	_, err := w.Write([]byte{byte(microsPerQuarter >> 16), byte(microsPerQuarter >> 8), byte(microsPerQuarter)})
+	return err
+}
+
+// buildEvents produces note-on/note-off events for every active step,
+// sorted by tick with note-offs ordered before note-ons at the same tick.
+func buildEvents(p *drum.Pattern, opts MIDIOptions) []event {
+	var events []event
+	for _, t := range p.Tracks {
+		note := opts.note(t.Name)
+		for step, on := range t.Steps {
+			if !on {
+				continue
+			}
+			start := uint32(step * ticksPerStep)
+			events = append(events,
+				event{tick: start, status: 0x90 | drumChannel, data1: note, data2: 100},
+				event{tick: start + ticksPerStep/2, status: 0x80 | drumChannel, data1: note, data2: 0},
+			)
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool { return less(events[i], events[j]) })
+	return events
+}
+
+func less(a, b event) bool {
+	if a.tick != b.tick {
+		return a.tick < b.tick
+	}
+	// Note-offs before note-ons at the same tick, so a step's release
+	// never suppresses the next step's attack.
+	return a.status&0xF0 == 0x80 && b.status&0xF0 == 0x90
+}
+
+func writeEvents(w io.Writer, events []event) error {
+	var last uint32
+	for _, e := range events {
+		if err := writeVLQ(w, e.tick-last); err != nil {
+			return err
+		}
+		last = e.tick
+		if _, err := w.Write([]byte{e.status, e.data1, e.data2}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeVLQ writes v as a MIDI variable-length quantity.
+func writeVLQ(w io.Writer, v uint32) error {
+	buf := []byte{byte(v & 0x7F)}
+	v >>= 7
+	for v > 0 {
+		buf = append([]byte{byte(v&0x7F) | 0x80}, buf...)
+		v >>= 7
+	}
+	_, err := w.Write(buf)
+	return err
+}
@@ -0,0 +1,75 @@
+package midi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jabley/golang-challenge-1"
+)
+
+func TestExportMIDIHeader(t *testing.T) {
+	p := &drum.Pattern{
+		Version: "0.808",
+		Tempo:   120,
+		Tracks: []drum.Track{
+			{ID: "0", Name: "kick", Steps: []bool{true, false, false, false, true, false, false, false, true, false, false, false, true, false, false, false}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := ExportMIDI(p, &buf, MIDIOptions{}); err != nil {
+		t.Fatalf("ExportMIDI returned error: %v", err)
+	}
+
+	data := buf.Bytes()
+	if len(data) < 14 || string(data[0:4]) != "MThd" {
+		t.Fatalf("missing MThd header, got %x", data)
+	}
+	if !bytes.Contains(data, []byte("MTrk")) {
+		t.Fatalf("missing MTrk chunk, got %x", data)
+	}
+	// Format 0, 1 track.
+	if data[8] != 0 || data[9] != 0 || data[10] != 0 || data[11] != 1 {
+		t.Errorf("expected format 0 with 1 track, got format=%d ntrks=%d", data[9], data[11])
+	}
+}
+
+func TestExportMIDIUnmappedTrackUsesDefaultNote(t *testing.T) {
+	p := &drum.Pattern{
+		Version: "0.808",
+		Tempo:   120,
+		Tracks:  []drum.Track{{ID: "0", Name: "cowbell", Steps: []bool{true}}},
+	}
+
+	var buf bytes.Buffer
+	if err := ExportMIDI(p, &buf, MIDIOptions{}); err != nil {
+		t.Fatalf("ExportMIDI returned error: %v", err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte{0x90 | drumChannel, DefaultNote, 100}) {
+		t.Error("expected a note-on event using DefaultNote for an unmapped track name")
+	}
+}
+
+func TestWriteVLQ(t *testing.T) {
+	tests := []struct {
+		in   uint32
+		want []byte
+	}{
+		{0, []byte{0x00}},
+		{0x40, []byte{0x40}},
+		{0x7F, []byte{0x7F}},
+		{0x80, []byte{0x81, 0x00}},
+		{0x2000, []byte{0xC0, 0x00}},
+		{0x3FFF, []byte{0xFF, 0x7F}},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := writeVLQ(&buf, tt.in); err != nil {
+			t.Fatalf("writeVLQ(%d) returned error: %v", tt.in, err)
+		}
+		if !bytes.Equal(buf.Bytes(), tt.want) {
+			t.Errorf("writeVLQ(%#x) = %x, want %x", tt.in, buf.Bytes(), tt.want)
+		}
+	}
+}
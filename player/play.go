@@ -0,0 +1,53 @@
+//go:build audio
+
+package player
+
+/*
+#cgo linux LDFLAGS: -lasound
+#include <alsa/asoundlib.h>
+#include <stdlib.h>
+
+static int play_pcm(short *samples, int nframes, int channels, int sampleRate) {
+	snd_pcm_t *handle;
+	int err;
+
+	if ((err = snd_pcm_open(&handle, "default", SND_PCM_STREAM_PLAYBACK, 0)) < 0) {
+		return err;
+	}
+	if ((err = snd_pcm_set_params(handle, SND_PCM_FORMAT_S16_LE, SND_PCM_ACCESS_RW_INTERLEAVED,
+			channels, sampleRate, 1, 500000)) < 0) {
+		snd_pcm_close(handle);
+		return err;
+	}
+
+	err = snd_pcm_writei(handle, samples, nframes);
+	if (err < 0) {
+		err = snd_pcm_recover(handle, err, 0);
+	}
+	if (err >= 0) {
+		snd_pcm_drain(handle);
+	}
+
+	snd_pcm_close(handle);
+	return err;
+}
+*/
+import "C"
+
+import "fmt"
+
+// playPCM streams samples (interleaved, channels-many per frame) to the
+// default ALSA device. Built only with `-tags audio`, which is what keeps
+// the cgo/libasound dependency out of the default `go build`.
+func playPCM(samples []int16, sampleRate, channels int) error {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	nframes := len(samples) / channels
+	ret := C.play_pcm((*C.short)(&samples[0]), C.int(nframes), C.int(channels), C.int(sampleRate))
+	if ret < 0 {
+		return fmt.Errorf("player: ALSA playback failed: %d", ret)
+	}
+	return nil
+}
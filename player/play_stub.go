@@ -0,0 +1,16 @@
+//go:build !audio
+
+package player
+
+import "errors"
+
+// ErrNoAudioBackend is returned by Play when this binary was built without
+// the audio backend (build tag "audio"), which is the default so that
+// importing player never pulls in cgo.
+var ErrNoAudioBackend = errors.New("player: built without the \"audio\" tag, no playback backend available; use Render instead")
+
+// playPCM is the no-op backend compiled in by default. Build with
+// `-tags audio` to link the real device backend in play.go instead.
+func playPCM(samples []int16, sampleRate, channels int) error {
+	return ErrNoAudioBackend
+}
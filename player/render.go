@@ -0,0 +1,179 @@
+// Package player renders a drum.Pattern to audio: Render mixes it down to
+// a WAV stream using nothing but the standard library, while Play pushes
+// the same mix to a live audio device through an optional, build-tagged
+// backend so the drum package itself never pulls in cgo.
+package player
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/jabley/golang-challenge-1"
+)
+
+// SampleRate is the output sample rate used when mixing. Loaded samples
+// are mixed frame for frame at this rate; their own sample rate is not
+// converted.
+const SampleRate = 44100
+
+const stepsPerPattern = 16
+const channels = 2
+
+// SampleDir is the default directory Play and Render load "<track
+// name>.wav" samples from.
+var SampleDir = "samples"
+
+// Player mixes a drum.Pattern's steps down to PCM audio, loading the
+// voice for each track through Resolver.
+type Player struct {
+	Resolver SampleResolver
+
+	// Swing delays odd-indexed (off-beat) 16th-note steps by
+	// swing/100 * (stepDuration/2), giving the pattern a shuffled feel.
+	// 0 (the default) plays the pattern perfectly quantized.
+	Swing int
+}
+
+// NewPlayer returns a Player that loads samples through resolver.
+func NewPlayer(resolver SampleResolver) *Player {
+	return &Player{Resolver: resolver}
+}
+
+// Render mixes p down to a stereo 16-bit PCM WAV stream and writes it to w.
+func (pl *Player) Render(p *drum.Pattern, w io.Writer) error {
+	mix, err := pl.mix(p, 0)
+	if err != nil {
+		return err
+	}
+	return writeWAV(w, SampleRate, channels, mix)
+}
+
+// Play mixes p down and streams seconds worth of audio to the platform
+// audio backend registered by this build (see play.go / play_stub.go).
+func (pl *Player) Play(p *drum.Pattern, seconds int) error {
+	mix, err := pl.mix(p, seconds)
+	if err != nil {
+		return err
+	}
+	return playPCM(mix, SampleRate, channels)
+}
+
+// mix renders one bar (16 steps) of p, looping it to fill at least
+// minSeconds when minSeconds > 0.
+func (pl *Player) mix(p *drum.Pattern, minSeconds int) ([]int16, error) {
+	if p.Tempo <= 0 {
+		return nil, fmt.Errorf("player: invalid tempo %v, must be > 0", p.Tempo)
+	}
+
+	voices := make([][]int16, len(p.Tracks))
+	for i, t := range p.Tracks {
+		samples, err := pl.loadVoice(t.Name)
+		if err != nil {
+			return nil, err
+		}
+		voices[i] = samples
+	}
+
+	stepDuration := 60 / float64(p.Tempo) / 4
+	stepFrames := int(stepDuration * SampleRate)
+	if stepFrames < 1 {
+		// An extreme Tempo can round a step down to zero frames; floor
+		// it at one so a bar always has non-zero length.
+		stepFrames = 1
+	}
+	swingFrames := int(float64(pl.Swing) / 100 * (stepDuration / 2) * SampleRate)
+	barFrames := stepFrames * stepsPerPattern
+
+	repeats := 1
+	if minSeconds > 0 {
+		barSeconds := float64(barFrames) / SampleRate
+		repeats = int(math.Ceil(float64(minSeconds) / barSeconds))
+		if repeats < 1 {
+			repeats = 1
+		}
+	}
+
+	frames := make([]int32, barFrames*repeats*channels)
+	for rep := 0; rep < repeats; rep++ {
+		base := rep * barFrames
+		for ti, t := range p.Tracks {
+			for step, on := range t.Steps {
+				if !on {
+					continue
+				}
+				offset := base + step*stepFrames
+				if step%2 == 1 {
+					offset += swingFrames
+				}
+				pl.mixVoice(frames, offset*channels, voices[ti])
+			}
+		}
+	}
+
+	return clampToInt16(frames), nil
+}
+
+// mixVoice adds a mono voice's samples into the stereo frame buffer
+// starting at offset, duplicating each sample across both channels.
+func (pl *Player) mixVoice(frames []int32, offset int, voice []int16) {
+	for i, s := range voice {
+		at := offset + i*channels
+		if at+1 >= len(frames) {
+			break
+		}
+		frames[at] += int32(s)
+		frames[at+1] += int32(s)
+	}
+}
+
+func (pl *Player) loadVoice(name string) ([]int16, error) {
+	r, err := pl.Resolver.Sample(name)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	w, err := readWAV(r)
+	if err != nil {
+		return nil, err
+	}
+	if w.channels == 1 {
+		return w.samples, nil
+	}
+
+	// Downmix a stereo sample to mono by taking the left channel.
+	mono := make([]int16, len(w.samples)/int(w.channels))
+	for i := range mono {
+		mono[i] = w.samples[i*int(w.channels)]
+	}
+	return mono, nil
+}
+
+// clampToInt16 saturates a mixed 32-bit buffer back down to int16 range.
+func clampToInt16(frames []int32) []int16 {
+	out := make([]int16, len(frames))
+	for i, f := range frames {
+		switch {
+		case f > 32767:
+			out[i] = 32767
+		case f < -32768:
+			out[i] = -32768
+		default:
+			out[i] = int16(f)
+		}
+	}
+	return out
+}
+
+// Render mixes p down to a stereo WAV stream written to w, loading
+// samples from SampleDir.
+func Render(p *drum.Pattern, w io.Writer) error {
+	return NewPlayer(NewDirResolver(SampleDir)).Render(p, w)
+}
+
+// Play mixes p down and plays seconds worth of audio, loading samples
+// from SampleDir.
+func Play(p *drum.Pattern, seconds int) error {
+	return NewPlayer(NewDirResolver(SampleDir)).Play(p, seconds)
+}
@@ -0,0 +1,34 @@
+package player
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jabley/golang-challenge-1"
+)
+
+func TestPlayerMixRejectsNonPositiveTempo(t *testing.T) {
+	pl := NewPlayer(MapResolver{})
+	p := &drum.Pattern{Version: "0.808", Tempo: 0}
+
+	if _, err := pl.mix(p, 0); err == nil {
+		t.Error("expected an error mixing a pattern with Tempo <= 0")
+	}
+}
+
+func TestPlayerMixTerminatesForExtremeTempo(t *testing.T) {
+	pl := NewPlayer(MapResolver{})
+	p := &drum.Pattern{Version: "0.808", Tempo: 2000000}
+
+	done := make(chan struct{})
+	go func() {
+		pl.mix(p, 1)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("mix did not return for an extreme tempo, stepFrames likely truncated to 0")
+	}
+}
@@ -0,0 +1,46 @@
+package player
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// SampleResolver locates the PCM sample used for a track, keyed by the
+// track's Name. Implementations may read from disk, an embedded asset
+// bundle, or an in-memory map built for tests.
+type SampleResolver interface {
+	Sample(name string) (io.ReadCloser, error)
+}
+
+// DirResolver resolves samples from WAV files named "<name>.wav" inside
+// Dir.
+type DirResolver struct {
+	Dir string
+}
+
+// NewDirResolver returns a SampleResolver that reads "<name>.wav" files
+// from dir.
+func NewDirResolver(dir string) DirResolver {
+	return DirResolver{Dir: dir}
+}
+
+// Sample opens <name>.wav under the resolver's directory.
+func (r DirResolver) Sample(name string) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(r.Dir, name+".wav"))
+}
+
+// MapResolver resolves samples from an in-memory map of track name to raw
+// WAV bytes, useful for tests and for bundling samples into a binary.
+type MapResolver map[string][]byte
+
+// Sample returns a reader over the WAV bytes registered for name.
+func (r MapResolver) Sample(name string) (io.ReadCloser, error) {
+	data, ok := r[name]
+	if !ok {
+		return nil, fmt.Errorf("player: no sample registered for track %q", name)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
@@ -0,0 +1,130 @@
+package player
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// ErrUnsupportedWAV is returned when a sample file isn't 16-bit PCM, the
+// only format this package mixes.
+var ErrUnsupportedWAV = errors.New("player: only 16-bit PCM WAV samples are supported")
+
+// wav is a decoded 16-bit PCM WAV file.
+type wav struct {
+	sampleRate uint32
+	channels   uint16
+	samples    []int16
+}
+
+// readWAV parses the RIFF/WAVE container down to its PCM samples.
+func readWAV(r io.Reader) (*wav, error) {
+	var riff struct {
+		ChunkID   [4]byte
+		ChunkSize uint32
+		Format    [4]byte
+	}
+	if err := binary.Read(r, binary.BigEndian, &riff); err != nil {
+		return nil, err
+	}
+	if string(riff.ChunkID[:]) != "RIFF" || string(riff.Format[:]) != "WAVE" {
+		return nil, ErrUnsupportedWAV
+	}
+
+	w := &wav{}
+	for {
+		var id [4]byte
+		var size uint32
+		if err := binary.Read(r, binary.BigEndian, &id); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+			return nil, err
+		}
+
+		switch string(id[:]) {
+		case "fmt ":
+			var fmtChunk struct {
+				AudioFormat   uint16
+				Channels      uint16
+				SampleRate    uint32
+				ByteRate      uint32
+				BlockAlign    uint16
+				BitsPerSample uint16
+			}
+			if err := binary.Read(r, binary.LittleEndian, &fmtChunk); err != nil {
+				return nil, err
+			}
+			if fmtChunk.AudioFormat != 1 || fmtChunk.BitsPerSample != 16 {
+				return nil, ErrUnsupportedWAV
+			}
+			w.sampleRate = fmtChunk.SampleRate
+			w.channels = fmtChunk.Channels
+			if extra := int64(size) - 16; extra > 0 {
+				if _, err := io.CopyN(io.Discard, r, extra); err != nil {
+					return nil, err
+				}
+			}
+		case "data":
+			w.samples = make([]int16, size/2)
+			if err := binary.Read(r, binary.LittleEndian, &w.samples); err != nil {
+				return nil, err
+			}
+		default:
+			if _, err := io.CopyN(io.Discard, r, int64(size)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if w.channels == 0 {
+		return nil, ErrUnsupportedWAV
+	}
+	return w, nil
+}
+
+// writeWAV emits samples (interleaved, channels-many per frame) as a
+// 16-bit PCM RIFF/WAVE stream.
+func writeWAV(w io.Writer, sampleRate int, channels int, samples []int16) error {
+	dataSize := uint32(len(samples) * 2)
+	blockAlign := uint16(channels * 2)
+	byteRate := uint32(sampleRate) * uint32(blockAlign)
+
+	hdr := struct {
+		ChunkID       [4]byte
+		ChunkSize     uint32
+		Format        [4]byte
+		Subchunk1ID   [4]byte
+		Subchunk1Size uint32
+		AudioFormat   uint16
+		Channels      uint16
+		SampleRate    uint32
+		ByteRate      uint32
+		BlockAlign    uint16
+		BitsPerSample uint16
+		Subchunk2ID   [4]byte
+		Subchunk2Size uint32
+	}{
+		ChunkSize:     36 + dataSize,
+		Subchunk1Size: 16,
+		AudioFormat:   1,
+		Channels:      uint16(channels),
+		SampleRate:    uint32(sampleRate),
+		ByteRate:      byteRate,
+		BlockAlign:    blockAlign,
+		BitsPerSample: 16,
+		Subchunk2Size: dataSize,
+	}
+	copy(hdr.ChunkID[:], "RIFF")
+	copy(hdr.Format[:], "WAVE")
+	copy(hdr.Subchunk1ID[:], "fmt ")
+	copy(hdr.Subchunk2ID[:], "data")
+
+	if err := binary.Write(w, binary.LittleEndian, &hdr); err != nil {
+		return err
+	}
+	return binary.Write(w, binary.LittleEndian, samples)
+}
@@ -0,0 +1,35 @@
+package player
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteReadWAVRoundTrip(t *testing.T) {
+	samples := []int16{0, 100, -100, 32767, -32768}
+
+	var buf bytes.Buffer
+	if err := writeWAV(&buf, SampleRate, 1, samples); err != nil {
+		t.Fatalf("writeWAV returned error: %v", err)
+	}
+
+	got, err := readWAV(&buf)
+	if err != nil {
+		t.Fatalf("readWAV returned error: %v", err)
+	}
+
+	if got.sampleRate != SampleRate {
+		t.Errorf("sampleRate = %d, want %d", got.sampleRate, SampleRate)
+	}
+	if got.channels != 1 {
+		t.Errorf("channels = %d, want 1", got.channels)
+	}
+	if len(got.samples) != len(samples) {
+		t.Fatalf("got %d samples, want %d", len(got.samples), len(samples))
+	}
+	for i, s := range samples {
+		if got.samples[i] != s {
+			t.Errorf("sample[%d] = %d, want %d", i, got.samples[i], s)
+		}
+	}
+}